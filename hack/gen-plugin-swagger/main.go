@@ -0,0 +1,267 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command gen-plugin-swagger walks every plugins.Plugin registered via plugins.RegisterPlugin,
+// and for each plugins.Command that attaches a non-nil Args struct, emits an OpenAPI 3
+// description of its /api/plugins/{name}/invoke endpoint plus a typed Go client under
+// pkg/client/{name}, so downstream tooling gets a generated surface instead of hand-crafting
+// SCM comments. Run it with `go generate ./...` or directly:
+//
+//	go run ./hack/gen-plugin-swagger -out pkg/client
+//
+// It must be run from a binary that has blank-imported the plugin packages it should cover,
+// since registration happens in each plugin's init().
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/jenkins-x/lighthouse/pkg/plugins"
+
+	// Blank-imported so its init() registers it with the plugins package; add one of these
+	// per plugin this generator should cover.
+	_ "github.com/jenkins-x/lighthouse/pkg/plugins/lifecycle"
+)
+
+type argField struct {
+	GoName      string
+	JSONName    string
+	GoType      string
+	OpenAPIType string
+	Required    bool
+}
+
+type commandSpec struct {
+	PluginName  string
+	Description string
+	Fields      []argField
+}
+
+func main() {
+	outDir := flag.String("out", "pkg/client", "directory under which <plugin>/{swagger.yaml,client.go} are written")
+	flag.Parse()
+
+	all := plugins.AllPlugins()
+	names := make([]string, 0, len(all))
+	for name := range all {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		p := all[name]
+		for _, cmd := range p.Commands {
+			if cmd.Args == nil {
+				continue
+			}
+			spec := commandSpec{
+				PluginName:  name,
+				Description: p.Description,
+				Fields:      argFields(cmd.Args),
+			}
+			dir := filepath.Join(*outDir, name)
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				fail(err)
+			}
+			if err := writeSwagger(dir, spec); err != nil {
+				fail(err)
+			}
+			if err := writeClient(dir, spec); err != nil {
+				fail(err)
+			}
+		}
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
+
+// argFields reflects over args (a struct, passed by value as plugins.Command.Args) and
+// describes each exported field by its json tag, so the generator doesn't need to know
+// anything about the plugin beyond that struct.
+func argFields(args interface{}) []argField {
+	t := reflect.TypeOf(args)
+	var fields []argField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" { // unexported
+			continue
+		}
+		jsonName := sf.Name
+		required := true
+		if tag := sf.Tag.Get("json"); tag != "" {
+			parts := strings.Split(tag, ",")
+			if parts[0] != "" && parts[0] != "-" {
+				jsonName = parts[0]
+			}
+			for _, p := range parts[1:] {
+				if p == "omitempty" {
+					required = false
+				}
+			}
+		}
+		fields = append(fields, argField{
+			GoName:      sf.Name,
+			JSONName:    jsonName,
+			GoType:      sf.Type.String(),
+			OpenAPIType: openAPIType(sf.Type),
+			Required:    required,
+		})
+	}
+	return fields
+}
+
+func openAPIType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	default:
+		return "string"
+	}
+}
+
+var swaggerTemplate = template.Must(template.New("swagger").Funcs(template.FuncMap{"title": strings.Title}).Parse(`# Code generated by hack/gen-plugin-swagger from the {{.PluginName}} plugin's registered
+# Args schema. DO NOT EDIT.
+openapi: 3.0.3
+info:
+  title: lighthouse {{.PluginName}} plugin
+  description: {{.Description}}
+  version: 1.0.0
+paths:
+  /api/plugins/{{.PluginName}}/invoke:
+    post:
+      summary: Invoke a {{.PluginName}} plugin command
+      operationId: invoke{{.PluginName | title}}
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/InvokeRequest'
+      responses:
+        '204':
+          description: the command was applied
+        '400':
+          description: the request was malformed
+components:
+  schemas:
+    InvokeRequest:
+      type: object
+      required: [org, repo, number{{range .Fields}}{{if .Required}}, {{.JSONName}}{{end}}{{end}}]
+      properties:
+        org:
+          type: string
+        repo:
+          type: string
+        number:
+          type: integer
+        pr:
+          type: boolean
+          default: false
+{{- range .Fields}}
+        {{.JSONName}}:
+          type: {{.OpenAPIType}}
+{{- end}}
+`))
+
+var clientTemplate = template.Must(template.New("client").Parse(`// Code generated by hack/gen-plugin-swagger from the {{.PluginName}} plugin's registered Args schema. DO NOT EDIT.
+
+// Package {{.PluginName}} is a typed client for the {{.PluginName}} plugin's
+// /api/plugins/{{.PluginName}}/invoke endpoint, for callers that want to drive its commands
+// without crafting a raw SCM comment.
+package {{.PluginName}}
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// InvokeRequest is the body accepted by /api/plugins/{{.PluginName}}/invoke.
+type InvokeRequest struct {
+	Org    string ` + "`json:\"org\"`" + `
+	Repo   string ` + "`json:\"repo\"`" + `
+	Number int    ` + "`json:\"number\"`" + `
+	PR     bool   ` + "`json:\"pr,omitempty\"`" + `
+{{- range .Fields}}
+	{{.GoName}} {{.GoType}} ` + "`json:\"{{.JSONName}}{{if not .Required}},omitempty{{end}}\"`" + `
+{{- end}}
+}
+
+// Client invokes {{.PluginName}} plugin commands through a lighthouse webhook server.
+type Client struct {
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient returns a Client that talks to the webhook server at baseURL,
+// e.g. "https://hook.example.com".
+func NewClient(baseURL string) *Client {
+	return &Client{BaseURL: baseURL, HTTPClient: http.DefaultClient}
+}
+
+// Invoke sends req to /api/plugins/{{.PluginName}}/invoke.
+func (c *Client) Invoke(req InvokeRequest) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	resp, err := c.HTTPClient.Post(c.BaseURL+"/api/plugins/{{.PluginName}}/invoke", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("{{.PluginName}} invoke returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+`))
+
+func writeSwagger(dir string, spec commandSpec) error {
+	f, err := os.Create(filepath.Join(dir, "swagger.yaml"))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return swaggerTemplate.Execute(f, spec)
+}
+
+func writeClient(dir string, spec commandSpec) error {
+	var buf strings.Builder
+	if err := clientTemplate.Execute(&buf, spec); err != nil {
+		return err
+	}
+	formatted, err := format.Source([]byte(buf.String()))
+	if err != nil {
+		return fmt.Errorf("formatting generated client.go: %w", err)
+	}
+	return os.WriteFile(filepath.Join(dir, "client.go"), formatted, 0644)
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package audit provides an append-only, hash-chained log of state transitions performed by
+// lighthouse plugins (label additions/removals, lifecycle transitions, and similar), so an
+// operator can later prove nobody silently edited or backdated an entry.
+package audit
+
+import "time"
+
+// Event is a single mutation performed by a plugin, recorded verbatim into the chain.
+type Event struct {
+	Timestamp     time.Time `json:"timestamp"`
+	Repo          string    `json:"repo"`
+	Issue         int       `json:"issue"`
+	Actor         string    `json:"actor"`
+	Command       string    `json:"command"`
+	Added         []string  `json:"added,omitempty"`
+	Removed       []string  `json:"removed,omitempty"`
+	PluginVersion string    `json:"plugin_version"`
+}
+
+// Record is an Event committed into the chain. Hash binds it to every record before it:
+// Hash = SHA256(PrevHash || canonicalJSON(Event)).
+type Record struct {
+	Event    Event  `json:"event"`
+	PrevHash string `json:"prev_hash"`
+	Hash     string `json:"hash"`
+}
+
+// SignedRoot is a digest over the chain as of Timestamp, signed with the operator's Ed25519 key
+// so a later `lhctl audit verify` can detect any record inserted, edited or removed before it.
+type SignedRoot struct {
+	Hash      string    `json:"hash"`
+	Timestamp time.Time `json:"timestamp"`
+	Signature string    `json:"signature"`
+}
+
+// Emitter records plugin-mutation events into the audit log. A nil Emitter is valid and simply
+// means auditing isn't configured for this deployment.
+type Emitter interface {
+	Record(event Event) error
+}
+
+// Sink persists Records and the signed roots rotated over them. Implementations include
+// FileSink (local filesystem) and WebhookSink; an S3-backed Sink can be added behind the same
+// interface without touching ChainEmitter.
+type Sink interface {
+	// Append persists record, which must be the next record after the current LastHash.
+	Append(record Record) error
+	// LastHash returns the Hash of the most recently appended record, or "" if the log is empty.
+	LastHash() (string, error)
+	// Records returns every record in append order, oldest first.
+	Records() ([]Record, error)
+	// PublishRoot persists a newly rotated SignedRoot.
+	PublishRoot(root SignedRoot) error
+	// LatestRoot returns the most recently published SignedRoot.
+	LatestRoot() (SignedRoot, error)
+}
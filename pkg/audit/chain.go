@@ -0,0 +1,112 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ChainEmitter is the default Emitter. It hashes every event onto the previous record's hash
+// and, on RotateRoot, signs the current chain head with an Ed25519 key so the root can be
+// published to a sink independent of the log itself.
+type ChainEmitter struct {
+	sink       Sink
+	signingKey ed25519.PrivateKey
+
+	mu sync.Mutex
+}
+
+// NewChainEmitter returns a ChainEmitter that appends to sink and signs roots with signingKey.
+func NewChainEmitter(sink Sink, signingKey ed25519.PrivateKey) *ChainEmitter {
+	return &ChainEmitter{sink: sink, signingKey: signingKey}
+}
+
+// Record appends event to the chain, computing its hash over the current chain head.
+func (e *ChainEmitter) Record(event Event) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	prevHash, err := e.sink.LastHash()
+	if err != nil {
+		return fmt.Errorf("reading chain head: %w", err)
+	}
+
+	hash, err := recordHash(prevHash, event)
+	if err != nil {
+		return err
+	}
+
+	return e.sink.Append(Record{Event: event, PrevHash: prevHash, Hash: hash})
+}
+
+// RotateRoot signs the current chain head and publishes it to the sink. It's intended to be
+// called on a timer (e.g. hourly) by whatever process owns the ChainEmitter.
+func (e *ChainEmitter) RotateRoot(now time.Time) (SignedRoot, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	head, err := e.sink.LastHash()
+	if err != nil {
+		return SignedRoot{}, fmt.Errorf("reading chain head: %w", err)
+	}
+
+	sig := ed25519.Sign(e.signingKey, []byte(head))
+	root := SignedRoot{Hash: head, Timestamp: now, Signature: hex.EncodeToString(sig)}
+	if err := e.sink.PublishRoot(root); err != nil {
+		return SignedRoot{}, err
+	}
+	return root, nil
+}
+
+// RunRootRotation calls RotateRoot every interval until ctx is cancelled, logging (rather than
+// returning) any error so one failed rotation doesn't stop the ones after it. Callers typically
+// run this in its own goroutine for the lifetime of the process that owns the ChainEmitter.
+func (e *ChainEmitter) RunRootRotation(ctx context.Context, interval time.Duration, log *logrus.Entry) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if _, err := e.RotateRoot(now); err != nil {
+				log.WithError(err).Error("Failed to rotate audit root.")
+			}
+		}
+	}
+}
+
+// recordHash computes SHA256(prevHash || canonicalJSON(event)). json.Marshal on Event is
+// already deterministic since Event has no maps and a fixed field order.
+func recordHash(prevHash string, event Event) (string, error) {
+	canonical, err := json.Marshal(event)
+	if err != nil {
+		return "", fmt.Errorf("canonicalizing event: %w", err)
+	}
+	sum := sha256.Sum256(append([]byte(prevHash), canonical...))
+	return hex.EncodeToString(sum[:]), nil
+}
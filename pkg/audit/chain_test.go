@@ -0,0 +1,159 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+	"time"
+)
+
+func newTestChain(t *testing.T) (*ChainEmitter, *memorySink, ed25519.PublicKey) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generating test key: %v", err)
+	}
+	sink := &memorySink{}
+	return NewChainEmitter(sink, priv), sink, pub
+}
+
+func TestChainEmitterRecordAndVerify(t *testing.T) {
+	emitter, sink, pub := newTestChain(t)
+
+	for i := 0; i < 3; i++ {
+		event := Event{Repo: "org/repo", Issue: i, Actor: "alice", Command: "lifecycle", Added: []string{"lifecycle/stale"}}
+		if err := emitter.Record(event); err != nil {
+			t.Fatalf("Record: %v", err)
+		}
+	}
+
+	if _, err := emitter.RotateRoot(time.Now()); err != nil {
+		t.Fatalf("RotateRoot: %v", err)
+	}
+
+	if err := Verify(sink, pub); err != nil {
+		t.Errorf("Verify on an untampered chain returned an error: %v", err)
+	}
+}
+
+func TestVerifyChainDetectsTamperedEvent(t *testing.T) {
+	emitter, sink, pub := newTestChain(t)
+
+	for i := 0; i < 2; i++ {
+		if err := emitter.Record(Event{Repo: "org/repo", Issue: i, Command: "lifecycle"}); err != nil {
+			t.Fatalf("Record: %v", err)
+		}
+	}
+	root, err := emitter.RotateRoot(time.Now())
+	if err != nil {
+		t.Fatalf("RotateRoot: %v", err)
+	}
+
+	records, err := sink.Records()
+	if err != nil {
+		t.Fatalf("Records: %v", err)
+	}
+	records[0].Event.Actor = "mallory"
+
+	if err := VerifyChain(records, root, pub); err == nil {
+		t.Error("VerifyChain did not detect a tampered event")
+	}
+}
+
+func TestVerifyChainDetectsReorderedRecords(t *testing.T) {
+	emitter, sink, pub := newTestChain(t)
+
+	for i := 0; i < 3; i++ {
+		if err := emitter.Record(Event{Repo: "org/repo", Issue: i, Command: "lifecycle"}); err != nil {
+			t.Fatalf("Record: %v", err)
+		}
+	}
+	root, err := emitter.RotateRoot(time.Now())
+	if err != nil {
+		t.Fatalf("RotateRoot: %v", err)
+	}
+
+	records, err := sink.Records()
+	if err != nil {
+		t.Fatalf("Records: %v", err)
+	}
+	records[1], records[2] = records[2], records[1]
+
+	if err := VerifyChain(records, root, pub); err == nil {
+		t.Error("VerifyChain did not detect reordered records")
+	}
+}
+
+func TestVerifyChainDetectsInvalidRootSignature(t *testing.T) {
+	emitter, sink, _ := newTestChain(t)
+
+	if err := emitter.Record(Event{Repo: "org/repo", Issue: 1, Command: "lifecycle"}); err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	root, err := emitter.RotateRoot(time.Now())
+	if err != nil {
+		t.Fatalf("RotateRoot: %v", err)
+	}
+
+	records, err := sink.Records()
+	if err != nil {
+		t.Fatalf("Records: %v", err)
+	}
+
+	otherPub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generating unrelated key: %v", err)
+	}
+	if err := VerifyChain(records, root, otherPub); err == nil {
+		t.Error("VerifyChain did not detect a root signed by a different key")
+	}
+}
+
+// memorySink is a minimal in-memory Sink for tests that don't want to touch the filesystem.
+type memorySink struct {
+	records []Record
+	root    SignedRoot
+}
+
+func (s *memorySink) Append(record Record) error {
+	s.records = append(s.records, record)
+	return nil
+}
+
+func (s *memorySink) LastHash() (string, error) {
+	if len(s.records) == 0 {
+		return "", nil
+	}
+	return s.records[len(s.records)-1].Hash, nil
+}
+
+func (s *memorySink) Records() ([]Record, error) {
+	out := make([]Record, len(s.records))
+	copy(out, s.records)
+	return out, nil
+}
+
+func (s *memorySink) PublishRoot(root SignedRoot) error {
+	s.root = root
+	return nil
+}
+
+func (s *memorySink) LatestRoot() (SignedRoot, error) {
+	return s.root, nil
+}
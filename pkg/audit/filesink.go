@@ -0,0 +1,122 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// FileSink persists the chain as newline-delimited JSON records in one file and the latest
+// signed root as JSON in a sibling file. It's the default Sink for single-node deployments.
+type FileSink struct {
+	recordsPath string
+	rootPath    string
+
+	mu sync.Mutex
+}
+
+// NewFileSink returns a FileSink backed by recordsPath and rootPath, creating neither until
+// the first write.
+func NewFileSink(recordsPath, rootPath string) *FileSink {
+	return &FileSink{recordsPath: recordsPath, rootPath: rootPath}
+}
+
+// Append implements Sink.
+func (s *FileSink) Append(record Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.OpenFile(s.recordsPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("opening audit log %s: %w", s.recordsPath, err)
+	}
+	defer f.Close()
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
+
+// LastHash implements Sink.
+func (s *FileSink) LastHash() (string, error) {
+	records, err := s.Records()
+	if err != nil {
+		return "", err
+	}
+	if len(records) == 0 {
+		return "", nil
+	}
+	return records[len(records)-1].Hash, nil
+}
+
+// Records implements Sink.
+func (s *FileSink) Records() ([]Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.Open(s.recordsPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("opening audit log %s: %w", s.recordsPath, err)
+	}
+	defer f.Close()
+
+	var records []Record
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("parsing audit log %s: %w", s.recordsPath, err)
+		}
+		records = append(records, record)
+	}
+	return records, scanner.Err()
+}
+
+// PublishRoot implements Sink.
+func (s *FileSink) PublishRoot(root SignedRoot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := json.Marshal(root)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.rootPath, data, 0644)
+}
+
+// LatestRoot implements Sink.
+func (s *FileSink) LatestRoot() (SignedRoot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.rootPath)
+	if err != nil {
+		return SignedRoot{}, fmt.Errorf("reading audit root %s: %w", s.rootPath, err)
+	}
+	var root SignedRoot
+	err = json.Unmarshal(data, &root)
+	return root, err
+}
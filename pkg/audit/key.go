@@ -0,0 +1,74 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// signingKeyFile is the key name operators are expected to use when mounting the audit signing
+// key as a Kubernetes Secret volume, e.g. the audit config's signing_key_dir set to
+// /etc/lighthouse/audit-key, mounting a Secret with a single "ed25519-private-key" entry.
+const signingKeyFile = "ed25519-private-key"
+
+// LoadSigningKeyFromSecret reads the Ed25519 private key mounted at
+// filepath.Join(secretDir, "ed25519-private-key"), hex-decoding its contents. It's meant to be
+// pointed at a Kubernetes Secret volume mount rather than accepting the key as a raw flag value,
+// so the key itself never appears in process arguments or config.
+func LoadSigningKeyFromSecret(secretDir string) (ed25519.PrivateKey, error) {
+	path := filepath.Join(secretDir, signingKeyFile)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading audit signing key from %s: %w", path, err)
+	}
+	key, err := hex.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, fmt.Errorf("decoding audit signing key in %s: %w", path, err)
+	}
+	if len(key) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("audit signing key in %s has %d bytes, want %d", path, len(key), ed25519.PrivateKeySize)
+	}
+	return ed25519.PrivateKey(key), nil
+}
+
+// publicKeyFile is the key name operators are expected to use when mounting just the audit
+// public key, e.g. into an `lhctl audit verify` sidecar that should never see the private half.
+const publicKeyFile = "ed25519-public-key"
+
+// LoadPublicKeyFromSecret reads the Ed25519 public key mounted at
+// filepath.Join(secretDir, "ed25519-public-key"), for verifiers that only need to check
+// signatures (such as `lhctl audit verify`) and so should never be handed the signing key.
+func LoadPublicKeyFromSecret(secretDir string) (ed25519.PublicKey, error) {
+	path := filepath.Join(secretDir, publicKeyFile)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading audit public key from %s: %w", path, err)
+	}
+	key, err := hex.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, fmt.Errorf("decoding audit public key in %s: %w", path, err)
+	}
+	if len(key) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("audit public key in %s has %d bytes, want %d", path, len(key), ed25519.PublicKeySize)
+	}
+	return ed25519.PublicKey(key), nil
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"fmt"
+)
+
+// Verify re-walks every record in the sink and confirms the hash chain is intact and that it
+// terminates at the sink's most recently published, signature-valid root. It is the engine
+// behind `lhctl audit verify`.
+func Verify(sink Sink, publicKey ed25519.PublicKey) error {
+	records, err := sink.Records()
+	if err != nil {
+		return fmt.Errorf("reading records: %w", err)
+	}
+	root, err := sink.LatestRoot()
+	if err != nil {
+		return fmt.Errorf("reading latest root: %w", err)
+	}
+	return VerifyChain(records, root, publicKey)
+}
+
+// VerifyChain confirms that records form an unbroken hash chain from the empty prefix and that
+// the chain's head matches root.Hash, and that root.Signature is a valid Ed25519 signature over
+// root.Hash under publicKey.
+func VerifyChain(records []Record, root SignedRoot, publicKey ed25519.PublicKey) error {
+	prevHash := ""
+	for i, rec := range records {
+		if rec.PrevHash != prevHash {
+			return fmt.Errorf("record %d: prev_hash %q does not match chain head %q; log may have been edited or reordered", i, rec.PrevHash, prevHash)
+		}
+		hash, err := recordHash(rec.PrevHash, rec.Event)
+		if err != nil {
+			return fmt.Errorf("record %d: %w", i, err)
+		}
+		if hash != rec.Hash {
+			return fmt.Errorf("record %d: hash %q does not match recomputed %q; log may have been tampered with", i, rec.Hash, hash)
+		}
+		prevHash = rec.Hash
+	}
+
+	if prevHash != root.Hash {
+		return fmt.Errorf("chain head %q does not match published root %q; entries may have been added or removed after the root was signed", prevHash, root.Hash)
+	}
+
+	sig, err := hex.DecodeString(root.Signature)
+	if err != nil {
+		return fmt.Errorf("decoding root signature: %w", err)
+	}
+	if !ed25519.Verify(publicKey, []byte(root.Hash), sig) {
+		return fmt.Errorf("root signature is invalid")
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// WebhookSink wraps another Sink for durable storage and additionally POSTs every appended
+// record and every published root to url, e.g. so a SIEM can ingest the audit trail in
+// near-real-time. Reads (LastHash, Records, LatestRoot) are served from the wrapped Sink.
+type WebhookSink struct {
+	Sink
+	url        string
+	httpClient *http.Client
+}
+
+// NewWebhookSink returns a WebhookSink that durably stores into sink and also posts to url.
+func NewWebhookSink(sink Sink, url string, httpClient *http.Client) *WebhookSink {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &WebhookSink{Sink: sink, url: url, httpClient: httpClient}
+}
+
+// Append implements Sink.
+func (s *WebhookSink) Append(record Record) error {
+	if err := s.Sink.Append(record); err != nil {
+		return err
+	}
+	return s.post(record)
+}
+
+// PublishRoot implements Sink.
+func (s *WebhookSink) PublishRoot(root SignedRoot) error {
+	if err := s.Sink.PublishRoot(root); err != nil {
+		return err
+	}
+	return s.post(root)
+}
+
+func (s *WebhookSink) post(payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	resp, err := s.httpClient.Post(s.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("posting to audit webhook %s: %w", s.url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("audit webhook %s returned status %d", s.url, resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package labels holds the names of labels lighthouse plugins apply, so two plugins never
+// drift apart on spelling the same label two different ways.
+package labels
+
+const (
+	// LifecycleActive is applied by `/lifecycle active`; an issue/PR with no lifecycle/* label
+	// is treated as active too.
+	LifecycleActive = "lifecycle/active"
+	// LifecyclePlanned marks an issue/PR with a committed ETA; see the lifecycle plugin's /eta.
+	LifecyclePlanned = "lifecycle/planned"
+	// LifecycleFrozen exempts an issue/PR from the automatic stale/rotten sweep indefinitely.
+	LifecycleFrozen = "lifecycle/frozen"
+	// LifecycleStale marks an issue/PR idle long enough to warrant a warning before it rots.
+	LifecycleStale = "lifecycle/stale"
+	// LifecycleRotten marks an issue/PR idle long enough to warrant closing it soon.
+	LifecycleRotten = "lifecycle/rotten"
+)
@@ -0,0 +1,89 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/jenkins-x/lighthouse/pkg/audit"
+	"github.com/jenkins-x/lighthouse/pkg/scmprovider"
+)
+
+// defaultRootRotationInterval is how often NewAgent rotates the audit root when AuditConfig
+// doesn't override it.
+const defaultRootRotationInterval = time.Hour
+
+// AuditConfig configures the audit.Emitter NewAgent wires into Agent.Audit.
+type AuditConfig struct {
+	// Enabled turns on the audit log. When false, Agent.Audit is nil and recording is a no-op.
+	Enabled bool `json:"enabled,omitempty"`
+	// RecordsPath and RootPath back an audit.FileSink.
+	RecordsPath string `json:"records_path,omitempty"`
+	RootPath    string `json:"root_path,omitempty"`
+	// SigningKeyDir is a directory, typically a mounted Kubernetes Secret volume, containing
+	// the Ed25519 signing key NewAgent loads with audit.LoadSigningKeyFromSecret.
+	SigningKeyDir string `json:"signing_key_dir,omitempty"`
+	// RootRotationInterval is how often the signed root is rotated; defaults to an hour.
+	RootRotationInterval time.Duration `json:"root_rotation_interval,omitempty"`
+}
+
+// NewAgent builds the Agent handed to a plugin's handler for an event against org/repo: it
+// resolves the audit Emitter from cfg.Audit (if enabled) and starts its root-rotation loop,
+// tied to ctx so the webhook server can stop it on shutdown. This is the construction point a
+// webhook server should call once per incoming event, scmClient scoped to that event.
+func NewAgent(ctx context.Context, cfg *Configuration, scmClient *scmprovider.Client, log *logrus.Entry) (Agent, error) {
+	agent := Agent{
+		SCMProviderClient: scmClient,
+		Logger:            log,
+		PluginConfig:      cfg,
+	}
+
+	if cfg == nil || !cfg.Audit.Enabled {
+		return agent, nil
+	}
+
+	emitter, err := newAuditEmitter(ctx, cfg.Audit, log)
+	if err != nil {
+		return Agent{}, fmt.Errorf("building audit emitter: %w", err)
+	}
+	agent.Audit = emitter
+	return agent, nil
+}
+
+// newAuditEmitter constructs the audit.ChainEmitter described by cfg and starts rotating its
+// signed root every cfg.RootRotationInterval until ctx is cancelled.
+func newAuditEmitter(ctx context.Context, cfg AuditConfig, log *logrus.Entry) (audit.Emitter, error) {
+	signingKey, err := audit.LoadSigningKeyFromSecret(cfg.SigningKeyDir)
+	if err != nil {
+		return nil, err
+	}
+
+	sink := audit.NewFileSink(cfg.RecordsPath, cfg.RootPath)
+	emitter := audit.NewChainEmitter(sink, signingKey)
+
+	interval := cfg.RootRotationInterval
+	if interval == 0 {
+		interval = defaultRootRotationInterval
+	}
+	go emitter.RunRootRotation(ctx, interval, log)
+
+	return emitter, nil
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lifecycle
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/lighthouse/pkg/scmprovider"
+	"github.com/sirupsen/logrus"
+
+	"github.com/jenkins-x/lighthouse/pkg/labels"
+	"github.com/jenkins-x/lighthouse/pkg/plugins"
+)
+
+// defaultExemptLabels skips the sweep for frozen issues, and for planned issues: the chunk0-5
+// state machine only allows planned -> frozen, so the sweep must never auto-stale a planned one.
+var defaultExemptLabels = []string{labels.LifecycleFrozen, labels.LifecyclePlanned}
+
+const (
+	defaultStaleAfter  = 90 * 24 * time.Hour
+	defaultRottenAfter = 30 * 24 * time.Hour
+	defaultCloseAfter  = 30 * 24 * time.Hour
+
+	staleCommentFmt = "Issues go stale after %s of inactivity.\n" +
+		"Mark the issue as fresh with `/remove-lifecycle stale`.\n" +
+		"Stale issues rot after an additional %s of inactivity and eventually close.\n\n/lifecycle stale"
+	rottenCommentFmt = "Stale issues rot after %s of inactivity.\n" +
+		"Mark the issue as fresh with `/remove-lifecycle rotten`.\n" +
+		"Rotten issues close after an additional %s of inactivity.\n\n/lifecycle rotten"
+	closeCommentFmt = "Rotten issues close after %s of inactivity.\n" +
+		"Reopen the issue with `/reopen`.\n" +
+		"Mark the issue as fresh again with `/remove-lifecycle rotten`.\n\n/close"
+)
+
+// lifecycleConfigFor resolves the plugins.LifecycleConfig that applies to org/repo, checking
+// org/repo, then org, then the * wildcard, and filling in defaults for anything left unset.
+func lifecycleConfigFor(cfg *plugins.Configuration, org, repo string) plugins.LifecycleConfig {
+	var resolved plugins.LifecycleConfig
+	if cfg != nil {
+		for _, key := range []string{org + "/" + repo, org, "*"} {
+			if c, ok := cfg.Lifecycle[key]; ok {
+				resolved = c
+				break
+			}
+		}
+	}
+	if resolved.StaleAfter == 0 {
+		resolved.StaleAfter = defaultStaleAfter
+	}
+	if resolved.RottenAfter == 0 {
+		resolved.RottenAfter = defaultRottenAfter
+	}
+	if resolved.CloseAfter == 0 {
+		resolved.CloseAfter = defaultCloseAfter
+	}
+	resolved.ExemptLabels = append(append([]string{}, defaultExemptLabels...), resolved.ExemptLabels...)
+	return resolved
+}
+
+// RunAutoLifecycle walks every open issue and PR in org/repo and transitions it through
+// active -> stale -> rotten -> closed based on the configured inactivity windows. It is meant
+// to be invoked periodically, e.g. from an `lhctl lifecycle sweep` command, and shares the
+// lifecycleClient interface and label vocabulary with the interactive /lifecycle command.
+func RunAutoLifecycle(pc plugins.Agent, org, repo string) error {
+	gc := pc.SCMProviderClient
+	log := pc.Logger
+	cfg := lifecycleConfigFor(pc.PluginConfig, org, repo)
+
+	issues, err := gc.ListIssues(org, repo)
+	if err != nil {
+		return fmt.Errorf("listing issues for %s/%s: %w", org, repo, err)
+	}
+
+	for _, issue := range issues {
+		if err := transitionIssue(gc, cfg, log, org, repo, issue); err != nil {
+			log.WithError(err).Errorf("Failed to evaluate lifecycle transition for %s/%s#%d.", org, repo, issue.Number)
+		}
+	}
+	return nil
+}
+
+// transitionIssue decides the next lifecycle state for issue based on how long it's been idle.
+// The SCM's "updated at" timestamp already advances on new comments, commits and label changes,
+// so using it as the idle clock gives human activity the reset the spec asks for for free.
+func transitionIssue(gc lifecycleClient, cfg plugins.LifecycleConfig, log *logrus.Entry, org, repo string, issue *scm.Issue) error {
+	if isExempt(issue.Labels, cfg.ExemptLabels) {
+		return nil
+	}
+	idle := time.Since(issue.Updated)
+
+	switch {
+	case scmprovider.HasLabel(labels.LifecycleRotten, issue.Labels):
+		if idle < cfg.CloseAfter {
+			return nil
+		}
+		return closeForLifecycle(gc, cfg, log, org, repo, issue.Number, issue.PullRequest)
+	case scmprovider.HasLabel(labels.LifecycleStale, issue.Labels):
+		if idle < cfg.RottenAfter {
+			return nil
+		}
+		return transitionTo(gc, cfg, log, org, repo, issue.Number, issue.PullRequest,
+			labels.LifecycleStale, labels.LifecycleRotten, fmt.Sprintf(rottenCommentFmt, cfg.RottenAfter, cfg.CloseAfter))
+	default:
+		if idle < cfg.StaleAfter {
+			return nil
+		}
+		return transitionTo(gc, cfg, log, org, repo, issue.Number, issue.PullRequest,
+			"", labels.LifecycleStale, fmt.Sprintf(staleCommentFmt, cfg.StaleAfter, cfg.RottenAfter))
+	}
+}
+
+func transitionTo(gc lifecycleClient, cfg plugins.LifecycleConfig, log *logrus.Entry, org, repo string, number int, pr bool, oldLabel, newLabel, comment string) error {
+	if cfg.DryRun {
+		log.Infof("[dry-run] would transition %s/%s#%d from %q to %q.", org, repo, number, oldLabel, newLabel)
+		return nil
+	}
+	if oldLabel != "" {
+		if err := gc.RemoveLabel(org, repo, number, oldLabel, pr); err != nil {
+			log.WithError(err).Errorf("Failed to remove %s label.", oldLabel)
+		}
+	}
+	if err := gc.AddLabel(org, repo, number, newLabel, pr); err != nil {
+		return err
+	}
+	return gc.CreateComment(org, repo, number, pr, comment)
+}
+
+func closeForLifecycle(gc lifecycleClient, cfg plugins.LifecycleConfig, log *logrus.Entry, org, repo string, number int, pr bool) error {
+	if cfg.DryRun {
+		log.Infof("[dry-run] would close %s/%s#%d as rotten.", org, repo, number)
+		return nil
+	}
+	if err := gc.CreateComment(org, repo, number, pr, fmt.Sprintf(closeCommentFmt, cfg.CloseAfter)); err != nil {
+		log.WithError(err).Errorf("Failed to comment before closing %s/%s#%d.", org, repo, number)
+	}
+	return gc.CloseIssue(org, repo, number, pr)
+}
+
+func isExempt(current []*scm.Label, exempt []string) bool {
+	for _, label := range current {
+		for _, ex := range exempt {
+			if label.Name == ex {
+				return true
+			}
+		}
+	}
+	return false
+}
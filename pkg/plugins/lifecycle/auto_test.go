@@ -0,0 +1,160 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lifecycle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/go-scm/scm"
+
+	"github.com/jenkins-x/lighthouse/pkg/plugins"
+)
+
+func TestIsExempt(t *testing.T) {
+	tests := []struct {
+		name    string
+		current []*scm.Label
+		want    bool
+	}{
+		{name: "frozen is exempt", current: []*scm.Label{{Name: "lifecycle/frozen"}}, want: true},
+		{name: "planned is exempt", current: []*scm.Label{{Name: "lifecycle/planned"}}, want: true},
+		{name: "stale is not exempt", current: []*scm.Label{{Name: "lifecycle/stale"}}, want: false},
+		{name: "no lifecycle labels is not exempt", current: nil, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isExempt(tt.current, defaultExemptLabels); got != tt.want {
+				t.Errorf("isExempt(%v) = %v, want %v", tt.current, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLifecycleConfigForDefaults(t *testing.T) {
+	cfg := lifecycleConfigFor(nil, "org", "repo")
+	if cfg.StaleAfter != defaultStaleAfter || cfg.RottenAfter != defaultRottenAfter || cfg.CloseAfter != defaultCloseAfter {
+		t.Errorf("lifecycleConfigFor(nil) = %+v, want the default windows", cfg)
+	}
+	if !containsLabel(cfg.ExemptLabels, "lifecycle/frozen") || !containsLabel(cfg.ExemptLabels, "lifecycle/planned") {
+		t.Errorf("lifecycleConfigFor(nil) ExemptLabels = %v, want it to include the built-in exemptions", cfg.ExemptLabels)
+	}
+}
+
+func TestLifecycleConfigForResolvesMostSpecificFirst(t *testing.T) {
+	pc := &plugins.Configuration{
+		Lifecycle: map[string]plugins.LifecycleConfig{
+			"*":        {StaleAfter: time.Hour},
+			"org":      {StaleAfter: 2 * time.Hour},
+			"org/repo": {StaleAfter: 3 * time.Hour},
+		},
+	}
+	cfg := lifecycleConfigFor(pc, "org", "repo")
+	if cfg.StaleAfter != 3*time.Hour {
+		t.Errorf("lifecycleConfigFor org/repo StaleAfter = %v, want 3h from the org/repo entry", cfg.StaleAfter)
+	}
+
+	cfg = lifecycleConfigFor(pc, "org", "other-repo")
+	if cfg.StaleAfter != 2*time.Hour {
+		t.Errorf("lifecycleConfigFor org/other-repo StaleAfter = %v, want 2h from the org entry", cfg.StaleAfter)
+	}
+
+	cfg = lifecycleConfigFor(pc, "other-org", "repo")
+	if cfg.StaleAfter != time.Hour {
+		t.Errorf("lifecycleConfigFor other-org/repo StaleAfter = %v, want 1h from the * entry", cfg.StaleAfter)
+	}
+}
+
+func TestTransitionIssue(t *testing.T) {
+	cfg := plugins.LifecycleConfig{
+		StaleAfter:   time.Hour,
+		RottenAfter:  time.Hour,
+		CloseAfter:   time.Hour,
+		ExemptLabels: defaultExemptLabels,
+	}
+
+	tests := []struct {
+		name       string
+		labels     []*scm.Label
+		idle       time.Duration
+		wantLabels []string
+		wantClosed bool
+	}{
+		{name: "fresh issue is left alone", labels: nil, idle: time.Minute, wantLabels: nil},
+		{name: "idle active issue goes stale", labels: nil, idle: 2 * time.Hour, wantLabels: []string{"lifecycle/stale"}},
+		{
+			name: "idle stale issue goes rotten", labels: []*scm.Label{{Name: "lifecycle/stale"}},
+			idle: 2 * time.Hour, wantLabels: []string{"lifecycle/rotten"},
+		},
+		{
+			name: "idle rotten issue closes", labels: []*scm.Label{{Name: "lifecycle/rotten"}},
+			idle: 2 * time.Hour, wantClosed: true,
+		},
+		{
+			name: "planned issue is exempt from the sweep", labels: []*scm.Label{{Name: "lifecycle/planned"}},
+			idle: 2 * time.Hour, wantLabels: []string{"lifecycle/planned"},
+		},
+		{
+			name: "frozen issue is exempt from the sweep", labels: []*scm.Label{{Name: "lifecycle/frozen"}},
+			idle: 2 * time.Hour, wantLabels: []string{"lifecycle/frozen"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gc := &fakeLifecycleClient{labels: tt.labels}
+			issue := &scm.Issue{Number: 1, Labels: tt.labels, Updated: time.Now().Add(-tt.idle)}
+
+			if err := transitionIssue(gc, cfg, testLogger(), "org", "repo", issue); err != nil {
+				t.Fatalf("transitionIssue returned error: %v", err)
+			}
+
+			if tt.wantClosed != gc.closed {
+				t.Errorf("transitionIssue closed = %v, want %v", gc.closed, tt.wantClosed)
+			}
+			if !tt.wantClosed {
+				assertLabelNames(t, gc.labels, tt.wantLabels)
+			}
+		})
+	}
+}
+
+func containsLabel(labels []string, name string) bool {
+	for _, l := range labels {
+		if l == name {
+			return true
+		}
+	}
+	return false
+}
+
+func assertLabelNames(t *testing.T, labels []*scm.Label, want []string) {
+	t.Helper()
+	var got []string
+	for _, l := range labels {
+		got = append(got, l.Name)
+	}
+	if len(got) != len(want) {
+		t.Errorf("labels = %v, want %v", got, want)
+		return
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Errorf("labels = %v, want %v", got, want)
+			return
+		}
+	}
+}
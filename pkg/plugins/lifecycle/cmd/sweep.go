@@ -0,0 +1,68 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cmd wires the lifecycle plugin's automatic sweep into lhctl as `lhctl lifecycle sweep`,
+// since nothing else in this tree ever calls lifecycle.RunAutoLifecycle.
+package cmd
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/jenkins-x/lighthouse/pkg/plugins"
+	"github.com/jenkins-x/lighthouse/pkg/plugins/lifecycle"
+	"github.com/jenkins-x/lighthouse/pkg/scmprovider"
+)
+
+type sweepOptions struct {
+	org  string
+	repo string
+}
+
+// NewLifecycleCommand returns the `lhctl lifecycle` parent command.
+func NewLifecycleCommand(scmClient *scmprovider.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lifecycle",
+		Short: "Operate on the lighthouse lifecycle plugin",
+	}
+	cmd.AddCommand(NewSweepCommand(scmClient))
+	return cmd
+}
+
+// NewSweepCommand returns `lhctl lifecycle sweep`, which runs one pass of
+// lifecycle.RunAutoLifecycle over org/repo using scmClient. It's meant to be invoked
+// periodically, e.g. from a CronJob, since the sweep has no timer of its own.
+func NewSweepCommand(scmClient *scmprovider.Client) *cobra.Command {
+	o := &sweepOptions{}
+	cmd := &cobra.Command{
+		Use:   "sweep",
+		Short: "Run one pass of the stale/rotten/close lifecycle sweep over a repo",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run(scmClient)
+		},
+	}
+	cmd.Flags().StringVar(&o.org, "org", "", "organisation owning the repo to sweep")
+	cmd.Flags().StringVar(&o.repo, "repo", "", "repo to sweep")
+	return cmd
+}
+
+func (o *sweepOptions) run(scmClient *scmprovider.Client) error {
+	pc := plugins.Agent{
+		SCMProviderClient: scmClient,
+		Logger:            logrus.WithField("plugin", "lifecycle"),
+	}
+	return lifecycle.RunAutoLifecycle(pc, o.org, o.repo)
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lifecycle
+
+import (
+	"io"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/lighthouse/pkg/scmprovider"
+	"github.com/sirupsen/logrus"
+)
+
+// fakeLifecycleClient is a minimal, in-memory lifecycleClient for unit tests that never talks
+// to a real SCM. AddLabel, RemoveLabel, CreateComment and CloseIssue record their effect on
+// the fake's state, GetIssueLabels reads it back, and the rest are no-ops.
+type fakeLifecycleClient struct {
+	labels   []*scm.Label
+	comments []string
+	closed   bool
+}
+
+func (f *fakeLifecycleClient) AddLabel(owner, repo string, number int, label string, pr bool) error {
+	f.labels = append(f.labels, &scm.Label{Name: label})
+	return nil
+}
+
+func (f *fakeLifecycleClient) RemoveLabel(owner, repo string, number int, label string, pr bool) error {
+	var kept []*scm.Label
+	for _, l := range f.labels {
+		if l.Name != label {
+			kept = append(kept, l)
+		}
+	}
+	f.labels = kept
+	return nil
+}
+
+func (f *fakeLifecycleClient) GetIssueLabels(org, repo string, number int, pr bool) ([]*scm.Label, error) {
+	return f.labels, nil
+}
+
+func (f *fakeLifecycleClient) CreateComment(owner, repo string, number int, pr bool, comment string) error {
+	f.comments = append(f.comments, comment)
+	return nil
+}
+
+func (f *fakeLifecycleClient) TeamHasMember(org, team, user string) (bool, error) {
+	return false, nil
+}
+
+func (f *fakeLifecycleClient) ListIssues(org, repo string) ([]*scm.Issue, error) {
+	return nil, nil
+}
+
+func (f *fakeLifecycleClient) CloseIssue(org, repo string, number int, pr bool) error {
+	f.closed = true
+	return nil
+}
+
+func (f *fakeLifecycleClient) EnsureStickyComment(org, repo string, number int, pr bool, tag, comment string) error {
+	return nil
+}
+
+func newTestEvent() *scmprovider.GenericCommentEvent {
+	return &scmprovider.GenericCommentEvent{
+		Action: scm.ActionCreate,
+		Repo:   scm.Repository{Namespace: "org", Name: "repo"},
+		Number: 5,
+		Author: scm.User{Login: "alice"},
+	}
+}
+
+func testLogger() *logrus.Entry {
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	return logrus.NewEntry(log)
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lifecycle
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/lighthouse/pkg/scmprovider"
+
+	"github.com/jenkins-x/lighthouse/pkg/plugins"
+)
+
+// LifecycleArgs is the structured payload this plugin's commands accept, used by the swagger
+// generator to describe /api/plugins/lifecycle/invoke and by the generated pkg/client/lifecycle
+// client, so callers don't have to craft a raw SCM comment to drive /close, /reopen or /lifecycle.
+type LifecycleArgs struct {
+	// Action is one of close, reopen, lifecycle or remove-lifecycle.
+	Action string `json:"action"`
+	// State is required for the lifecycle and remove-lifecycle actions: active, frozen, stale,
+	// rotten or planned.
+	State string `json:"state,omitempty"`
+}
+
+// Invoke renders args as the equivalent comment body and runs it through the same
+// GenericCommentHandler used for real comments, so the invoke endpoint can never drift from
+// what a user typing the command directly would get.
+func Invoke(pc plugins.Agent, e scmprovider.GenericCommentEvent, args LifecycleArgs) error {
+	switch args.Action {
+	case "close":
+		e.Body = "/close"
+	case "reopen":
+		e.Body = "/reopen"
+	case "lifecycle":
+		e.Body = "/lifecycle " + args.State
+	case "remove-lifecycle":
+		e.Body = "/remove-lifecycle " + args.State
+	default:
+		return fmt.Errorf("unknown lifecycle action %q", args.Action)
+	}
+	return lifecycleHandleGenericComment(nil, pc, e)
+}
+
+// invokeRequest mirrors pkg/client/lifecycle.InvokeRequest: it's the JSON body
+// InvokeHandler accepts. It is written by hand; hack/gen-plugin-swagger does not generate or
+// update it, so it must be kept in step with LifecycleArgs and the generated client manually.
+type invokeRequest struct {
+	Org    string `json:"org"`
+	Repo   string `json:"repo"`
+	Number int    `json:"number"`
+	PR     bool   `json:"pr,omitempty"`
+	Action string `json:"action"`
+	State  string `json:"state,omitempty"`
+}
+
+// InvokeHandler serves /api/plugins/lifecycle/invoke: it decodes an invokeRequest, builds the
+// equivalent GenericCommentEvent, and runs it through Invoke, so HTTP callers get exactly the
+// same behaviour (including audit recording) as a user typing the command in a comment.
+func InvokeHandler(pc plugins.Agent) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req invokeRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, fmt.Sprintf("decoding request: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		e := scmprovider.GenericCommentEvent{
+			Action: scm.ActionCreate,
+			Repo:   scm.Repository{Namespace: req.Org, Name: req.Repo},
+			Number: req.Number,
+			IsPR:   req.PR,
+		}
+		if err := Invoke(pc, e, LifecycleArgs{Action: req.Action, State: req.State}); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// invokePath is the route InvokeHandler serves, matching the swagger spec hack/gen-plugin-swagger
+// emits for this plugin (see pkg/client/lifecycle/swagger.yaml).
+const invokePath = "/api/plugins/lifecycle/invoke"
+
+// RegisterInvokeHandler registers InvokeHandler on mux at invokePath. A webhook server wires
+// this in alongside RegisterPlugin for every plugin whose Command.Args is non-nil.
+func RegisterInvokeHandler(mux *http.ServeMux, pc plugins.Agent) {
+	mux.Handle(invokePath, InvokeHandler(pc))
+}
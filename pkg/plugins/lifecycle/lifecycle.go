@@ -17,22 +17,58 @@ limitations under the License.
 package lifecycle
 
 import (
+	"fmt"
 	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/jenkins-x/go-scm/scm"
 	"github.com/jenkins-x/lighthouse/pkg/scmprovider"
 	"github.com/sirupsen/logrus"
 
+	"github.com/jenkins-x/lighthouse/pkg/audit"
 	"github.com/jenkins-x/lighthouse/pkg/labels"
 	"github.com/jenkins-x/lighthouse/pkg/pluginhelp"
 	"github.com/jenkins-x/lighthouse/pkg/plugins"
 )
 
 var (
-	lifecycleLabels = []string{labels.LifecycleActive, labels.LifecycleFrozen, labels.LifecycleStale, labels.LifecycleRotten}
-	lifecycleRe     = regexp.MustCompile(`(?mi)^/(?:lh-)?(remove-)?lifecycle (active|frozen|stale|rotten)\s*$`)
+	lifecycleLabels = []string{labels.LifecycleActive, labels.LifecycleFrozen, labels.LifecycleStale, labels.LifecycleRotten, labels.LifecyclePlanned}
+	lifecycleRe     = regexp.MustCompile(`(?mi)^/(?:lh-)?(remove-)?lifecycle (active|frozen|stale|rotten|planned)\s*$`)
+	etaRe           = regexp.MustCompile(`(?mi)^/eta\s+(\S+)\s*$`)
+	etaDurationRe   = regexp.MustCompile(`(?i)^(\d+)([dwm])$`)
+
+	// defaultUniquePrefixes is applied on top of whatever a Configuration supplies, so the
+	// historical "only one lifecycle/* label at a time" behaviour keeps working unconfigured,
+	// and the planned/<YYYY-MM> labels an /eta leaves behind stay mutually exclusive too.
+	defaultUniquePrefixes = []string{"lifecycle/", "planned/"}
+)
+
+// lifecycle states, modelled explicitly so handleOne can reject moves that skip a required
+// step instead of silently swapping labels: active -> planned -> frozen, and
+// active -> stale -> rotten. legalTransitions lists every direct move that is allowed.
+const (
+	stateActive  = "active"
+	statePlanned = "planned"
+	stateFrozen  = "frozen"
+	stateStale   = "stale"
+	stateRotten  = "rotten"
 )
 
+// legalTransitions lists, for each current state, the states a /lifecycle command may move an
+// issue into directly. Anything absent here (e.g. planned -> stale) is rejected with a comment
+// telling the user to /remove-lifecycle the current state first.
+var legalTransitions = map[string]map[string]bool{
+	stateActive:  {statePlanned: true, stateFrozen: true, stateStale: true},
+	statePlanned: {stateFrozen: true},
+	stateFrozen:  {stateActive: true, statePlanned: true},
+	stateStale:   {stateActive: true, stateFrozen: true, stateRotten: true},
+	stateRotten:  {stateActive: true, stateFrozen: true},
+}
+
+const etaCommentTag = "<!-- lighthouse:lifecycle-eta -->"
+
 const pluginName = "lifecycle"
 
 var (
@@ -42,6 +78,7 @@ var (
 		Commands: []plugins.Command{{
 			Filter:                func(e scmprovider.GenericCommentEvent) bool { return e.Action == scm.ActionCreate },
 			GenericCommentHandler: lifecycleHandleGenericComment,
+			Args:                  LifecycleArgs{},
 			Help: []pluginhelp.Command{{
 				Usage:       "/close",
 				Description: "Closes an issue or PR.",
@@ -55,11 +92,17 @@ var (
 				WhoCanUse:   "Authors and collaborators on the repository can trigger this command.",
 				Examples:    []string{"/reopen", "/lh-reopen"},
 			}, {
-				Usage:       "/[remove-]lifecycle <frozen|stale|rotten>",
-				Description: "Flags an issue or PR as frozen/stale/rotten",
+				Usage:       "/[remove-]lifecycle <active|frozen|stale|rotten|planned>",
+				Description: "Flags an issue or PR as frozen/stale/rotten, or as planned for future work",
+				Featured:    false,
+				WhoCanUse:   "Anyone can trigger this command, unless the resulting label is restricted.",
+				Examples:    []string{"/lifecycle frozen", "/remove-lifecycle stale", "/lh-lifecycle rotten", "/lifecycle planned"},
+			}, {
+				Usage:       "/eta <duration|date>",
+				Description: "Records an ETA for a planned issue or PR and labels it planned/<YYYY-MM>",
 				Featured:    false,
 				WhoCanUse:   "Anyone can trigger this command.",
-				Examples:    []string{"/lifecycle frozen", "/remove-lifecycle stale", "/lh-lifecyle rotten"},
+				Examples:    []string{"/eta 2w", "/eta 1m", "/eta 2024-06-01"},
 			}},
 		}},
 	}
@@ -77,6 +120,11 @@ type lifecycleClient interface {
 	AddLabel(owner, repo string, number int, label string, pr bool) error
 	RemoveLabel(owner, repo string, number int, label string, pr bool) error
 	GetIssueLabels(org, repo string, number int, pr bool) ([]*scm.Label, error)
+	CreateComment(owner, repo string, number int, pr bool, comment string) error
+	TeamHasMember(org, team, user string) (bool, error)
+	ListIssues(org, repo string) ([]*scm.Issue, error)
+	CloseIssue(org, repo string, number int, pr bool) error
+	EnsureStickyComment(org, repo string, number int, pr bool, tag, comment string) error
 }
 
 func lifecycleHandleGenericComment(_ []string, pc plugins.Agent, e scmprovider.GenericCommentEvent) error {
@@ -88,54 +136,270 @@ func lifecycleHandleGenericComment(_ []string, pc plugins.Agent, e scmprovider.G
 	if err := handleClose(gc, log, &e); err != nil {
 		return err
 	}
-	return handle(gc, log, &e)
+	if err := handle(gc, pc.PluginConfig, pc.Audit, log, &e); err != nil {
+		return err
+	}
+	return handleETA(gc, pc.PluginConfig, pc.Audit, log, &e)
 }
 
-func handle(gc lifecycleClient, log *logrus.Entry, e *scmprovider.GenericCommentEvent) error {
+func handle(gc lifecycleClient, cfg *plugins.Configuration, emitter audit.Emitter, log *logrus.Entry, e *scmprovider.GenericCommentEvent) error {
 	for _, mat := range lifecycleRe.FindAllStringSubmatch(e.Body, -1) {
-		if err := handleOne(gc, log, e, mat); err != nil {
+		if err := handleOne(gc, cfg, emitter, log, e, mat); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func handleOne(gc lifecycleClient, log *logrus.Entry, e *scmprovider.GenericCommentEvent, mat []string) error {
-	org := e.Repo.Namespace
-	repo := e.Repo.Name
-	number := e.Number
-
+func handleOne(gc lifecycleClient, cfg *plugins.Configuration, emitter audit.Emitter, log *logrus.Entry, e *scmprovider.GenericCommentEvent, mat []string) error {
 	remove := mat[1] != ""
 	cmd := mat[2]
 	lbl := "lifecycle/" + cmd
 
-	// Let's start simple and allow anyone to add/remove frozen, stale, rotten labels.
-	// Adjust if we find evidence of the community abusing these labels.
-	labels, err := gc.GetIssueLabels(org, repo, number, e.IsPR)
+	command := "lifecycle"
+	if remove {
+		command = "remove-lifecycle"
+	}
+
+	if !remove {
+		rejected, err := rejectIllegalTransition(gc, log, e, cmd)
+		if err != nil {
+			log.WithError(err).Errorf("Failed to determine current lifecycle state.")
+		} else if rejected {
+			return nil
+		}
+	}
+
+	return applyLabel(gc, cfg, emitter, log, e, command, lbl, remove)
+}
+
+// rejectIllegalTransition checks the issue's current lifecycle state against legalTransitions
+// and, if desired isn't reachable directly from it, posts a comment telling the user to
+// /remove-lifecycle the current state first and reports rejected=true so the caller stops.
+func rejectIllegalTransition(gc lifecycleClient, log *logrus.Entry, e *scmprovider.GenericCommentEvent, desired string) (bool, error) {
+	org := e.Repo.Namespace
+	repo := e.Repo.Name
+
+	existing, err := gc.GetIssueLabels(org, repo, e.Number, e.IsPR)
+	if err != nil {
+		return false, err
+	}
+
+	current := currentLifecycleState(existing)
+	if current == desired || legalTransitions[current][desired] {
+		return false, nil
+	}
+
+	comment := fmt.Sprintf(
+		"@%s: this issue is currently `lifecycle/%s`; run `/remove-lifecycle %s` before applying `/lifecycle %s`.",
+		e.Author.Login, current, current, desired)
+	return true, gc.CreateComment(org, repo, e.Number, e.IsPR, comment)
+}
+
+// currentLifecycleState returns the lifecycle state implied by existing labels, defaulting to
+// active when none of the lifecycle/* labels are present.
+func currentLifecycleState(existing []*scm.Label) string {
+	for _, state := range []string{statePlanned, stateFrozen, stateStale, stateRotten} {
+		if scmprovider.HasLabel("lifecycle/"+state, existing) {
+			return state
+		}
+	}
+	return stateActive
+}
+
+// applyLabel adds or removes lbl on e, honouring any unique-prefix and restricted-label rules
+// configured for the target org/repo. It is the shared label mutator behind every command
+// (today /lifecycle and /eta) that needs prefix-exclusive label families.
+func applyLabel(gc lifecycleClient, cfg *plugins.Configuration, emitter audit.Emitter, log *logrus.Entry, e *scmprovider.GenericCommentEvent, command, lbl string, remove bool) error {
+	org := e.Repo.Namespace
+	repo := e.Repo.Name
+	number := e.Number
+
+	existing, err := gc.GetIssueLabels(org, repo, number, e.IsPR)
 	if err != nil {
 		log.WithError(err).Errorf("Failed to get labels.")
 	}
 
 	// If the label exists and we asked for it to be removed, remove it.
-	if scmprovider.HasLabel(lbl, labels) && remove {
-		return gc.RemoveLabel(org, repo, number, lbl, e.IsPR)
+	if scmprovider.HasLabel(lbl, existing) && remove {
+		if err := gc.RemoveLabel(org, repo, number, lbl, e.IsPR); err != nil {
+			return err
+		}
+		recordAudit(emitter, log, org, repo, number, e.Author.Login, command, nil, []string{lbl})
+		return nil
+	}
+
+	// Nothing to do: either the label is already absent and we asked for removal, or it's
+	// already present and we asked for it to be added.
+	if scmprovider.HasLabel(lbl, existing) || remove {
+		return nil
 	}
 
-	// If the label does not exist and we asked for it to be added,
-	// remove other existing lifecycle labels and add it.
-	if !scmprovider.HasLabel(lbl, labels) && !remove {
-		for _, label := range lifecycleLabels {
-			if label != lbl && scmprovider.HasLabel(label, labels) {
-				if err := gc.RemoveLabel(org, repo, number, label, e.IsPR); err != nil {
-					log.WithError(err).Errorf("GitHub failed to remove the following label: %s", label)
+	if restricted, ok := restrictedLabelFor(cfg, org, repo, lbl); ok {
+		allowed, err := commenterAllowed(gc, restricted, org, e.Author.Login)
+		if err != nil {
+			log.WithError(err).Errorf("Failed to check whether %s may apply %s.", e.Author.Login, lbl)
+		}
+		if !allowed {
+			return gc.CreateComment(org, repo, number, e.IsPR, restrictedLabelComment(e.Author.Login, restricted))
+		}
+	}
+
+	// Strip any other label sharing lbl's unique prefix before adding the new one.
+	var removed []string
+	if prefix, ok := uniquePrefixFor(cfg, org, repo, lbl); ok {
+		for _, label := range existing {
+			if label.Name != lbl && strings.HasPrefix(label.Name, prefix) {
+				if err := gc.RemoveLabel(org, repo, number, label.Name, e.IsPR); err != nil {
+					log.WithError(err).Errorf("GitHub failed to remove the following label: %s", label.Name)
+					continue
 				}
+				removed = append(removed, label.Name)
 			}
 		}
+	}
 
-		if err := gc.AddLabel(org, repo, number, lbl, e.IsPR); err != nil {
-			log.WithError(err).Errorf("GitHub failed to add the following label: %s", lbl)
-		}
+	if err := gc.AddLabel(org, repo, number, lbl, e.IsPR); err != nil {
+		log.WithError(err).Errorf("GitHub failed to add the following label: %s", lbl)
+		return nil
 	}
+	recordAudit(emitter, log, org, repo, number, e.Author.Login, command, []string{lbl}, removed)
 
 	return nil
 }
+
+// recordAudit emits an audit.Event for a completed label mutation. A nil emitter (auditing not
+// configured for this deployment) is a no-op.
+func recordAudit(emitter audit.Emitter, log *logrus.Entry, org, repo string, number int, actor, command string, added, removed []string) {
+	if emitter == nil {
+		return
+	}
+	event := audit.Event{
+		Timestamp:     time.Now(),
+		Repo:          org + "/" + repo,
+		Issue:         number,
+		Actor:         actor,
+		Command:       command,
+		Added:         added,
+		Removed:       removed,
+		PluginVersion: pluginName,
+	}
+	if err := emitter.Record(event); err != nil {
+		log.WithError(err).Errorf("Failed to record audit event for %s/%s#%d.", org, repo, number)
+	}
+}
+
+// uniquePrefixFor returns the unique prefix lbl belongs to, if any, merging the org/repo's
+// configured plugins.Configuration.Label.UniquePrefixes with the built-in lifecycle/ and
+// planned/ prefixes.
+func uniquePrefixFor(cfg *plugins.Configuration, org, repo, lbl string) (string, bool) {
+	prefixes := defaultUniquePrefixes
+	if cfg != nil {
+		prefixes = append(append([]string{}, defaultUniquePrefixes...), cfg.Label.UniquePrefixes...)
+	}
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(lbl, prefix) {
+			return prefix, true
+		}
+	}
+	return "", false
+}
+
+// restrictedLabelFor looks up the plugins.RestrictedLabel entry governing lbl, checking
+// org/repo, then org, then the * wildcard, in that order of specificity.
+func restrictedLabelFor(cfg *plugins.Configuration, org, repo, lbl string) (plugins.RestrictedLabel, bool) {
+	if cfg == nil {
+		return plugins.RestrictedLabel{}, false
+	}
+	for _, key := range []string{org + "/" + repo, org, "*"} {
+		for _, rl := range cfg.Label.RestrictedLabels[key] {
+			if rl.Label == lbl {
+				return rl, true
+			}
+		}
+	}
+	return plugins.RestrictedLabel{}, false
+}
+
+// commenterAllowed reports whether user may apply a restricted label, either because they're
+// named directly in AllowedUsers or because they belong to one of the AllowedTeams.
+func commenterAllowed(gc lifecycleClient, rl plugins.RestrictedLabel, org, user string) (bool, error) {
+	for _, allowed := range rl.AllowedUsers {
+		if strings.EqualFold(allowed, user) {
+			return true, nil
+		}
+	}
+	for _, team := range rl.AllowedTeams {
+		member, err := gc.TeamHasMember(org, team, user)
+		if err != nil {
+			return false, err
+		}
+		if member {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func restrictedLabelComment(user string, rl plugins.RestrictedLabel) string {
+	var who []string
+	for _, team := range rl.AllowedTeams {
+		who = append(who, "team `"+team+"`")
+	}
+	for _, u := range rl.AllowedUsers {
+		who = append(who, "@"+u)
+	}
+	return fmt.Sprintf("@%s: the `%s` label is restricted and can only be applied by %s.", user, rl.Label, strings.Join(who, ", "))
+}
+
+// handleETA implements /eta <duration|date>: it persists the ETA as a sticky comment and
+// mirrors it into a planned/<YYYY-MM> label, which participates in the same unique-prefix
+// stripping as lifecycle/* so only the most recent ETA's label sticks around.
+func handleETA(gc lifecycleClient, cfg *plugins.Configuration, emitter audit.Emitter, log *logrus.Entry, e *scmprovider.GenericCommentEvent) error {
+	mat := etaRe.FindStringSubmatch(e.Body)
+	if mat == nil {
+		return nil
+	}
+	org := e.Repo.Namespace
+	repo := e.Repo.Name
+	number := e.Number
+
+	eta, err := parseETA(mat[1], time.Now())
+	if err != nil {
+		return gc.CreateComment(org, repo, number, e.IsPR, fmt.Sprintf("@%s: %v", e.Author.Login, err))
+	}
+
+	comment := fmt.Sprintf("%s\nETA: **%s**", etaCommentTag, eta.Format("2006-01-02"))
+	if err := gc.EnsureStickyComment(org, repo, number, e.IsPR, etaCommentTag, comment); err != nil {
+		log.WithError(err).Errorf("Failed to update ETA sticky comment.")
+	}
+
+	return applyLabel(gc, cfg, emitter, log, e, "eta", "planned/"+eta.Format("2006-01"), false)
+}
+
+// parseETA accepts Nd/Nw/Nm durations relative to now, or an RFC3339 or YYYY-MM-DD date.
+func parseETA(s string, now time.Time) (time.Time, error) {
+	if mat := etaDurationRe.FindStringSubmatch(s); mat != nil {
+		n, err := strconv.Atoi(mat[1])
+		if err != nil {
+			return time.Time{}, err
+		}
+		switch strings.ToLower(mat[2]) {
+		case "d":
+			return now.AddDate(0, 0, n), nil
+		case "w":
+			return now.AddDate(0, 0, n*7), nil
+		case "m":
+			return now.AddDate(0, n, 0), nil
+		}
+	}
+
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, nil
+	}
+
+	return time.Time{}, fmt.Errorf("could not parse %q as an ETA: expected Nd, Nw, Nm, or an RFC3339/YYYY-MM-DD date", s)
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lifecycle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/go-scm/scm"
+
+	"github.com/jenkins-x/lighthouse/pkg/plugins"
+)
+
+func TestCurrentLifecycleState(t *testing.T) {
+	tests := []struct {
+		name   string
+		labels []*scm.Label
+		want   string
+	}{
+		{name: "no lifecycle labels defaults to active", labels: nil, want: stateActive},
+		{name: "planned", labels: []*scm.Label{{Name: "lifecycle/planned"}}, want: statePlanned},
+		{name: "frozen", labels: []*scm.Label{{Name: "lifecycle/frozen"}}, want: stateFrozen},
+		{name: "stale", labels: []*scm.Label{{Name: "lifecycle/stale"}}, want: stateStale},
+		{name: "rotten", labels: []*scm.Label{{Name: "lifecycle/rotten"}}, want: stateRotten},
+		{name: "unrelated label ignored", labels: []*scm.Label{{Name: "kind/bug"}}, want: stateActive},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := currentLifecycleState(tt.labels); got != tt.want {
+				t.Errorf("currentLifecycleState(%v) = %q, want %q", tt.labels, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRejectIllegalTransition(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  string
+		desired  string
+		rejected bool
+	}{
+		{name: "active to planned is legal", current: stateActive, desired: statePlanned, rejected: false},
+		{name: "active to rotten is illegal", current: stateActive, desired: stateRotten, rejected: true},
+		{name: "planned to frozen is legal", current: statePlanned, desired: stateFrozen, rejected: false},
+		{name: "planned to stale is illegal", current: statePlanned, desired: stateStale, rejected: true},
+		{name: "same state is a no-op, not rejected", current: stateStale, desired: stateStale, rejected: false},
+		{name: "rotten to stale is illegal", current: stateRotten, desired: stateStale, rejected: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var existing []*scm.Label
+			if tt.current != stateActive {
+				existing = []*scm.Label{{Name: "lifecycle/" + tt.current}}
+			}
+			gc := &fakeLifecycleClient{labels: existing}
+			e := newTestEvent()
+			rejected, err := rejectIllegalTransition(gc, testLogger(), e, tt.desired)
+			if err != nil {
+				t.Fatalf("rejectIllegalTransition returned error: %v", err)
+			}
+			if rejected != tt.rejected {
+				t.Errorf("rejectIllegalTransition(%s -> %s) rejected = %v, want %v", tt.current, tt.desired, rejected, tt.rejected)
+			}
+		})
+	}
+}
+
+func TestUniquePrefixFor(t *testing.T) {
+	tests := []struct {
+		name       string
+		cfg        *plugins.Configuration
+		lbl        string
+		wantPrefix string
+		wantOK     bool
+	}{
+		{name: "built-in lifecycle prefix with nil config", cfg: nil, lbl: "lifecycle/stale", wantPrefix: "lifecycle/", wantOK: true},
+		{name: "built-in planned prefix with nil config", cfg: nil, lbl: "planned/2026-08", wantPrefix: "planned/", wantOK: true},
+		{name: "no matching prefix", cfg: nil, lbl: "kind/bug", wantOK: false},
+		{
+			name: "configured prefix on top of built-ins",
+			cfg:  &plugins.Configuration{Label: plugins.Label{UniquePrefixes: []string{"priority/"}}},
+			lbl:  "priority/high", wantPrefix: "priority/", wantOK: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prefix, ok := uniquePrefixFor(tt.cfg, "org", "repo", tt.lbl)
+			if ok != tt.wantOK || prefix != tt.wantPrefix {
+				t.Errorf("uniquePrefixFor(%q) = (%q, %v), want (%q, %v)", tt.lbl, prefix, ok, tt.wantPrefix, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestRestrictedLabelFor(t *testing.T) {
+	cfg := &plugins.Configuration{
+		Label: plugins.Label{
+			RestrictedLabels: map[string][]plugins.RestrictedLabel{
+				"org/repo": {{Label: "lifecycle/frozen", AllowedUsers: []string{"alice"}}},
+				"org":      {{Label: "lifecycle/rotten", AllowedTeams: []string{"maintainers"}}},
+			},
+		},
+	}
+
+	rl, ok := restrictedLabelFor(cfg, "org", "repo", "lifecycle/frozen")
+	if !ok || rl.Label != "lifecycle/frozen" {
+		t.Errorf("expected org/repo entry to match, got %+v, ok=%v", rl, ok)
+	}
+
+	rl, ok = restrictedLabelFor(cfg, "org", "other-repo", "lifecycle/rotten")
+	if !ok || rl.Label != "lifecycle/rotten" {
+		t.Errorf("expected org-level fallback to match, got %+v, ok=%v", rl, ok)
+	}
+
+	if _, ok := restrictedLabelFor(cfg, "org", "repo", "lifecycle/stale"); ok {
+		t.Errorf("expected no entry for an unrestricted label")
+	}
+
+	if _, ok := restrictedLabelFor(nil, "org", "repo", "lifecycle/frozen"); ok {
+		t.Errorf("expected nil config to never restrict anything")
+	}
+}
+
+func TestParseETA(t *testing.T) {
+	now := time.Date(2026, 7, 27, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		in      string
+		want    time.Time
+		wantErr bool
+	}{
+		{name: "days", in: "5d", want: now.AddDate(0, 0, 5)},
+		{name: "weeks", in: "2w", want: now.AddDate(0, 0, 14)},
+		{name: "months", in: "1m", want: now.AddDate(0, 1, 0)},
+		{name: "rfc3339 date", in: "2026-08-15T00:00:00Z", want: time.Date(2026, 8, 15, 0, 0, 0, 0, time.UTC)},
+		{name: "plain date", in: "2026-08-15", want: time.Date(2026, 8, 15, 0, 0, 0, 0, time.UTC)},
+		{name: "garbage", in: "not-a-date", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseETA(tt.in, now)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseETA(%q) expected an error, got none", tt.in)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseETA(%q) returned error: %v", tt.in, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("parseETA(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
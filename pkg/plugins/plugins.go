@@ -0,0 +1,124 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package plugins holds the registry and shared configuration every chat-ops plugin
+// (lifecycle, label, and friends) is handed when a webhook event comes in.
+package plugins
+
+import (
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/jenkins-x/lighthouse/pkg/audit"
+	"github.com/jenkins-x/lighthouse/pkg/pluginhelp"
+	"github.com/jenkins-x/lighthouse/pkg/scmprovider"
+)
+
+// Agent bundles everything a plugin's handler needs: an SCM client scoped to the event, a
+// logger, the resolved plugin configuration for the org/repo the event belongs to, and the
+// audit Emitter (nil if this deployment has auditing disabled) to record mutations into.
+type Agent struct {
+	SCMProviderClient *scmprovider.Client
+	Logger            *logrus.Entry
+	PluginConfig      *Configuration
+	Audit             audit.Emitter
+}
+
+// Configuration is the root of plugins.yaml: the label, lifecycle and other per-org/repo
+// behaviour every registered plugin reads from.
+type Configuration struct {
+	// Label configures label-family behaviour shared by every plugin that mutates labels.
+	Label Label `json:"label,omitempty"`
+	// Lifecycle configures the automatic stale/rotten/close sweep, keyed by "*", an org, or
+	// an "org/repo".
+	Lifecycle map[string]LifecycleConfig `json:"lifecycle,omitempty"`
+	// Audit configures the signed, hash-chained audit log every plugin mutation is recorded
+	// into; see NewAgent.
+	Audit AuditConfig `json:"audit,omitempty"`
+}
+
+// LifecycleConfig configures the automatic stale -> rotten -> close sweep for a repo.
+type LifecycleConfig struct {
+	StaleAfter   time.Duration `json:"stale_after,omitempty"`
+	RottenAfter  time.Duration `json:"rotten_after,omitempty"`
+	CloseAfter   time.Duration `json:"close_after,omitempty"`
+	ExemptLabels []string      `json:"exempt_labels,omitempty"`
+	DryRun       bool          `json:"dry_run,omitempty"`
+}
+
+// Label configures the unique-prefix and restricted-label mechanisms shared by any plugin
+// that adds labels (today, lifecycle; eventually priority/severity/kind and similar).
+type Label struct {
+	// UniquePrefixes lists label prefixes (e.g. "priority/") where only one label sharing the
+	// prefix may be present on an issue/PR at a time; adding one strips any sibling.
+	UniquePrefixes []string `json:"unique_prefixes,omitempty"`
+	// RestrictedLabels is keyed by "*", an org, or an "org/repo", and lists labels that may
+	// only be applied by the named users or team members.
+	RestrictedLabels map[string][]RestrictedLabel `json:"restricted_labels,omitempty"`
+}
+
+// RestrictedLabel gates Label behind team or user membership: only AllowedUsers or members of
+// AllowedTeams may apply it.
+type RestrictedLabel struct {
+	Label        string   `json:"label"`
+	AllowedTeams []string `json:"allowed_teams,omitempty"`
+	AllowedUsers []string `json:"allowed_users,omitempty"`
+}
+
+// Command is a chat-ops command a plugin registers: Filter decides whether
+// GenericCommentHandler should run for a given comment event, Help documents it for
+// `/help`, and Args optionally describes the structured payload the generated invoke
+// endpoint accepts for this command.
+type Command struct {
+	Filter                func(e scmprovider.GenericCommentEvent) bool
+	GenericCommentHandler func(match []string, pc Agent, e scmprovider.GenericCommentEvent) error
+	Help                  []pluginhelp.Command
+	Args                  interface{}
+}
+
+// Plugin is a single registered plugin: a human-readable description, a help provider, and
+// the chat-ops commands it handles.
+type Plugin struct {
+	Description  string
+	HelpProvider func(config *Configuration, enabledRepos []string) (*pluginhelp.PluginHelp, error)
+	Commands     []Command
+}
+
+var (
+	pluginsMu sync.Mutex
+	plugins   = map[string]Plugin{}
+)
+
+// RegisterPlugin adds plugin to the registry under name. It's typically called from an init()
+// function in the plugin's own package.
+func RegisterPlugin(name string, plugin Plugin) {
+	pluginsMu.Lock()
+	defer pluginsMu.Unlock()
+	plugins[name] = plugin
+}
+
+// AllPlugins returns a snapshot of every registered plugin, keyed by name.
+func AllPlugins() map[string]Plugin {
+	pluginsMu.Lock()
+	defer pluginsMu.Unlock()
+	out := make(map[string]Plugin, len(plugins))
+	for name, p := range plugins {
+		out[name] = p
+	}
+	return out
+}